@@ -1,12 +1,11 @@
 package soopchat
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
-	"reflect"
-	"strings"
 	"sync"
 	"time"
 
@@ -15,8 +14,9 @@ import (
 )
 
 // NewClient 함수는 Client 구조체를
-// 초기화하여 생성한다.
-func NewClient(token Token) (*Client, error) {
+// 초기화하여 생성한다. opts로 WithCodec 등의 옵션을 전달해
+// 기본 동작을 재정의할 수 있다.
+func NewClient(token Token, opts ...Option) (*Client, error) {
 	// StreamerID가 있어야 SocketAddress 및 ChatRoom 설정하므로
 	// 필수 토큰이다. 없을 경우 에러를 반환한다.
 	if token.StreamerID == "" {
@@ -27,17 +27,52 @@ func NewClient(token Token) (*Client, error) {
 	httpClient := resty.New()
 	httpClient.SetTimeout(time.Duration(2 * time.Second))
 
-	return &Client{
-		Token:           token,
-		read:            make(chan []byte, 1024),
-		handshake:       make([][]byte, 2),
-		channelPassword: "",
-		apiService:      apiService{http: httpClient},
-	}, nil
+	c := &Client{
+		Token:             token,
+		read:              make(chan readItem, readBufferSize),
+		writeCh:           make(chan writeRequest),
+		handshake:         make([][]byte, 2),
+		channelPassword:   "",
+		apiService:        apiService{http: httpClient},
+		ReconnectPolicy:   DefaultReconnectPolicy(),
+		codec:             DefaultSoopCodec{},
+		keepaliveInterval: defaultKeepaliveInterval,
+		keepaliveTimeout:  defaultKeepaliveInterval * 2,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// defaultKeepaliveInterval는 WithKeepalive로 재정의하지 않았을 때
+// KEEPALIVE 프레임을 전송하는 주기다.
+const defaultKeepaliveInterval = 1 * time.Minute
+
+// readBufferSize는 c.read 채널의 버퍼 크기다.
+const readBufferSize = 1024
+
+// WithKeepalive 함수는 KEEPALIVE 전송 주기(interval)와, 그 사이
+// 어떤 프레임도 수신하지 못했을 때 연결이 끊어진 것으로 간주할
+// idle 타임아웃(timeout)을 지정한다. 모바일/가정용 NAT처럼
+// 네트워크가 불안정한 환경에서 값을 조정할 때 사용한다.
+//
+// timeout은 채팅 서버가 KEEPALIVE에 응답 프레임을 보내는 채널을
+// 전제로 한다. 서버가 아무 응답도 주지 않는 조용한 채널에서는
+// timeout을 그 채널에서 예상되는 가장 긴 무응답 구간보다 넉넉히
+// 크게 잡아야, 멀쩡한 연결이 주기적으로 끊기는 일을 피할 수 있다.
+func WithKeepalive(interval, timeout time.Duration) Option {
+	return func(c *Client) {
+		c.keepaliveInterval = interval
+		c.keepaliveTimeout = timeout
+	}
 }
 
 // Connect 메서드는 채팅 서버 연결에 필요한
-// 과정을 수행한다.
+// 과정을 수행한다. 연결 중 읽기/쓰기 실패가 발생하면
+// ReconnectPolicy에 따라 재연결을 시도한다.
 func (c *Client) Connect(password ...string) error {
 	// 패스워드가 있다면 필드에 값을 대입한다.
 	if len(password) > 0 {
@@ -66,6 +101,47 @@ func (c *Client) Connect(password ...string) error {
 		}
 	}
 
+	// 웹소켓 연결/핸드쉐이크/수신 처리를 수행하고, 실패할 경우
+	// ReconnectPolicy가 허용하는 범위 내에서 재연결을 시도한다.
+	// c.reconnectAttempt는 JOIN 핸드쉐이크가 성공할 때마다 0으로
+	// 리셋되므로(setHandshake 참고), 재시도 한도는 연속 실패에만
+	// 적용되고 수 시간 연결을 유지하다 끊긴 경우에는 소진되지 않는다.
+	for {
+		err := c.connectOnce()
+		if err == nil {
+			return nil
+		}
+
+		if c.reconnectAttempt >= c.ReconnectPolicy.MaxRetries {
+			return err
+		}
+
+		backoff := c.ReconnectPolicy.backoff(c.reconnectAttempt)
+		c.reconnectAttempt++
+
+		if c.onReconnect != nil {
+			c.onReconnect(c.reconnectAttempt, err)
+		}
+
+		time.Sleep(backoff)
+	}
+}
+
+// connectOnce 메서드는 Socket Address를 새로 가져오고
+// (CHDOMAIN/CHPT가 재연결 사이에 바뀌었을 수 있으므로)
+// 웹소켓을 새로 연결해 한 차례의 연결 수명을 처리한다.
+func (c *Client) connectOnce() error {
+	// 이전 소켓은 processSocket에서 이미 닫혔으므로
+	// 새로 연결할 수 있도록 비워준다.
+	c.socket = nil
+
+	// processSocket은 반환되기 전에 이전 reader 고루틴을 join하므로,
+	// 핸드쉐이크 실패 등으로 일찍 끝난 연결이 마지막으로 내보낸
+	// readItem{err}이 여기 남아 있을 수 있다. 비우지 않으면 새로 만든
+	// 정상 소켓의 startParser가 이 오래된 에러를 먼저 읽어 멀쩡한
+	// 연결을 곧바로 끊어버린다.
+	c.read = make(chan readItem, readBufferSize)
+
 	// 자동으로 Socket Address 및 Chat Room를 가져옵니다.
 	err := c.apiService.setSocketData(c)
 	if err != nil {
@@ -132,7 +208,8 @@ func (c *Client) executeHandshake(svc int) error {
 func (c *Client) setHandshake(svc int) error {
 	// 핸드쉐이크를 전송하고 에러가 있을 경우
 	// onConnect 콜백에 false를 전달하고 에러를 반환한다.
-	err := c.socket.WriteMessage(websocket.BinaryMessage, c.handshake[svc-1])
+	// writer 고루틴을 거쳐 다른 쓰기(KEEPALIVE 등)와 직렬화된다.
+	err := c.write(c.handshake[svc-1])
 	if err != nil {
 		if c.onConnect != nil {
 			c.onConnect(false)
@@ -145,8 +222,12 @@ func (c *Client) setHandshake(svc int) error {
 	}
 
 	// 채널 접속에 성공할 경우
-	// onConnect 콜백에 true를 전달한다.
+	// 재연결 시도 횟수를 리셋하고 onConnect 콜백에 true를 전달한다.
+	// 이렇게 해야 ReconnectPolicy.MaxRetries가 연속 실패만 세고,
+	// 오래 연결을 유지하다 끊긴 경우에는 소진되지 않는다.
 	if svc == svc_JOINCH {
+		c.reconnectAttempt = 0
+
 		if c.onConnect != nil {
 			c.onConnect(true)
 		}
@@ -158,38 +239,50 @@ func (c *Client) setHandshake(svc int) error {
 // processSocket 메서드는 웹소켓으로
 // 들어오는 데이터를 처리한다.
 func (c *Client) processSocket() error {
-	// 함수가 종료되기 전에 소켓을 닫는다.
-	defer c.socket.Close()
-
-	// WaitGroup을 생성해 작업 완료까지 대기한다.
+	// WaitGroup으로 reader/writer/pingpong 세 고루틴을 모두 추적한다.
 	wg := sync.WaitGroup{}
-	wg.Add(1)
+	wg.Add(3)
 
 	// 웹소켓으로 넘어오는 데이터를 비동기 처리한다.
 	// 이 때 에러가 발생하면 작업이 완료된다.
 	go c.reader(&wg)
 
+	// gorilla/websocket은 동시 쓰기를 허용하지 않으므로, 핸드쉐이크/
+	// KEEPALIVE/SendChatMessage가 모두 거치는 writer 고루틴을 연결마다 새로 띄운다.
+	writeStop := make(chan struct{})
+	go c.writer(writeStop, &wg)
+
 	// 아빠 안잔다.
-	c.pingpong()
-	defer c.pingpongTimer.Stop()
+	// stop 채널을 닫아 재연결 시마다 쌓이는 고루틴 누수를 막는다.
+	stop := make(chan struct{})
+	c.pingpong(stop, &wg)
+
+	// defer는 LIFO로 실행되므로 아래 순서로 정리된다:
+	// 1) 소켓을 닫아 reader의 블로킹된 ReadMessage를 풀어준다.
+	// 2) writer/pingpong에 종료를 알리고, 세 고루틴이 모두 끝날 때까지
+	//    기다린다. connectOnce가 재연결하기 전에 이전 연결의 고루틴이
+	//    남아 새 소켓/c.read 채널을 건드리지 않는다고 보장하려면, 여기서
+	//    반드시 join해야 한다.
+	defer func() {
+		close(writeStop)
+		close(stop)
+		wg.Wait()
+	}()
+	defer c.socket.Close()
 
 	// 로그인 핸드쉐이크
 	// 이 때 에러가 발생하면 작업이 완료된다.
 	err := c.executeHandshake(svc_LOGIN)
 	if err != nil {
-		wg.Done()
 		return err
 	}
 
 	// 웹소켓으로 넘어오는 데이터를 분석/가공한다.
 	err = c.startParser()
 	if err != nil {
-		wg.Done()
 		return err
 	}
 
-	// 모든 작업이 완료될 때까지 대기한다.
-	wg.Wait()
 	return nil
 }
 
@@ -200,35 +293,50 @@ func (c *Client) reader(wg *sync.WaitGroup) {
 	// 작업을 완료시킨다.
 	defer wg.Done()
 
+	// idle 타임아웃을 설정한다. KEEPALIVE를 포함해 어떤 프레임이든
+	// 수신하면 매번 갱신하므로, 반쯤 끊어진 TCP 연결(모바일/가정용
+	// NAT에서 흔함)이 응답 없이 영원히 블록되지 않는다.
+	// 로컬 쓰기 성공 여부로는 갱신하지 않는다 — 절반만 끊어진 연결에서도
+	// WriteMessage는 커널 송신 버퍼에 쓰는 순간 성공하므로, 쓰기를
+	// 근거로 데드라인을 늦추면 바로 이 케이스를 못 잡게 된다.
+	c.socket.SetReadDeadline(time.Now().Add(c.keepaliveTimeout))
+
 	// 작업이 완료될 때까지 계속 웹소켓으로 들어오는 데이터를
 	// 리시버의 read 필드로 전달한다.
-	// 에러가 발생할 경우 read 필드에 error 를 전달한다.
+	// 에러가 발생할 경우 분류된 에러를 담아 전달하고 루프를 종료한다.
+	// (재연결 여부는 Connect 쪽에서 ReconnectPolicy에 따라 결정한다.)
 	for {
 		_, msg, err := c.socket.ReadMessage()
 		if err != nil {
-			c.read <- []byte(fmt.Sprintf("error: %s", err.Error()))
-			continue
+			c.read <- readItem{err: classifyReadError(err)}
+			return
 		}
 
-		c.read <- msg
+		c.socket.SetReadDeadline(time.Now().Add(c.keepaliveTimeout))
+		c.read <- readItem{msg: msg}
 	}
 }
 
 // startParser 메서드는 read 필드로 전달된 데이터를
 // 처리하여 콜백 함수로 전달한다.
 func (c *Client) startParser() error {
-	for msg := range c.read {
-		if strings.HasPrefix(string(msg), "error: ") {
+	for item := range c.read {
+		if item.err != nil {
 			if c.onError != nil {
-				c.onError(errors.New(string(msg)))
+				c.onError(item.err)
 			}
+
+			// 읽기 실패이므로 루프를 종료해 Connect가 재연결을 판단하게 한다.
+			return item.err
 		}
 
+		msg := item.msg
+
 		if c.onRawMessage != nil {
 			c.onRawMessage(fmt.Sprintf("%q", msg))
 		}
 
-		svc, err := getServiceCode(msg)
+		svc, body, err := c.codec.DecodeFrame(msg)
 		if err != nil {
 			if c.onError != nil {
 				c.onError(err)
@@ -247,7 +355,7 @@ func (c *Client) startParser() error {
 			}
 		case svc_JOINCH: // 채널 입장
 			if c.onJoinChannel != nil {
-				if b := c.parseJoinChannel(msg); b {
+				if b := c.parseJoinChannel(body); b {
 					c.onJoinChannel(true)
 				} else {
 					c.onJoinChannel(false)
@@ -255,12 +363,12 @@ func (c *Client) startParser() error {
 			}
 		case svc_CHUSER: // 입장/퇴장
 			if c.onUserLists != nil {
-				m := c.parseUserJoin(msg)
+				m := c.parseUserJoin(body)
 				c.onUserLists(m)
 			}
 		case svc_CHATMESG: // Chat
 			if c.onChatMessage != nil {
-				m, err := c.parseChatMessage(msg)
+				m, err := c.parseChatMessage(body)
 				if err != nil {
 					if c.onError != nil {
 						c.onError(err)
@@ -271,7 +379,7 @@ func (c *Client) startParser() error {
 			}
 		case svc_SENDBALLOON: // 별풍선
 			if c.onBalloon != nil {
-				m, err := c.parseBalloon(msg)
+				m, err := c.parseBalloon(body)
 				if err != nil {
 					if c.onError != nil {
 						c.onError(err)
@@ -282,7 +390,7 @@ func (c *Client) startParser() error {
 			}
 		case svc_ADCON_EFFECT: // 애드벌룬
 			if c.onAdballoon != nil {
-				m, err := c.parseAdballoon(msg)
+				m, err := c.parseAdballoon(body)
 				if err != nil {
 					if c.onError != nil {
 						c.onError(err)
@@ -293,7 +401,7 @@ func (c *Client) startParser() error {
 			}
 		case svc_FOLLOW_ITEM, svc_FOLLOW_ITEM_EFFECT: // 신규 구독 / 연속 구독
 			if c.onSubscription != nil {
-				m, err := c.parseSubscription(msg, svc)
+				m, err := c.parseSubscription(body, svc)
 				if err != nil {
 					if c.onError != nil {
 						c.onError(err)
@@ -304,7 +412,7 @@ func (c *Client) startParser() error {
 			}
 		case svc_SENDADMINNOTICE: // 어드민 메시지
 			if c.onAdminNotice != nil {
-				m, err := c.parseAdminNotice(msg)
+				m, err := c.parseAdminNotice(body)
 				if err != nil {
 					if c.onError != nil {
 						c.onError(err)
@@ -315,7 +423,7 @@ func (c *Client) startParser() error {
 			}
 		case svc_MISSION: // 도전미션
 			if c.onMission != nil {
-				m, err := c.parseMission(msg)
+				m, err := c.parseMission(body)
 				if err != nil {
 					if c.onError != nil {
 						c.onError(err)
@@ -333,30 +441,53 @@ func (c *Client) startParser() error {
 // SendChatMessage 메서드는 채팅 채널에 채팅 데이터를 전송한다.
 // 메시지를 보낼 때 실패한 경우 에러를 반환한다.
 func (c *Client) SendChatMessage(message string) error {
+	return c.SendChatMessageContext(context.Background(), message)
+}
+
+// SendChatMessageContext 메서드는 SendChatMessage와 같지만
+// ctx가 취소되면 writer 고루틴이 기록을 끝내기 전이라도 반환한다.
+func (c *Client) SendChatMessageContext(ctx context.Context, message string) error {
 	if c.Token.authTicket == "" {
 		return errors.New("cannot non-member send message")
 	}
 
-	var tBuf []string
-	tBuf = append(tBuf, "\f", message, "\f", "0", "\f")
-	bodyBuf := makeBuffer(tBuf)
-	headerBuf := makeHeader(5, len(bodyBuf), 0)
+	packet, err := c.codec.EncodeChat(message)
+	if err != nil {
+		return err
+	}
 
-	packet := append(headerBuf, bodyBuf...)
-	return c.socket.WriteMessage(websocket.BinaryMessage, packet)
+	return c.writeContext(ctx, packet)
 }
 
 // pingpong 메서드는 매 1분마다 ping 데이터를
-// 전송한다.
-func (c *Client) pingpong() {
-	c.pingpongTimer = time.NewTicker(1 * time.Minute)
+// 전송한다. 1분은 기본 주기이며 WithKeepalive의 interval로
+// 바꿀 수 있다. stop 채널이 닫히면 고루틴을 종료해
+// 재연결 시마다 고루틴이 쌓이는 것을 막는다. wg는 processSocket이
+// 재연결 전에 이 고루틴이 완전히 끝났는지 확인하는 데 쓰인다.
+func (c *Client) pingpong(stop <-chan struct{}, wg *sync.WaitGroup) {
+	c.pingpongTimer = time.NewTicker(c.keepaliveInterval)
 
 	go func() {
-		for range c.pingpongTimer.C {
-			bodyBuf := makeBuffer([]string{"\f"})
-			headerbuf := makeHeader(svc_KEEPALIVE, len(bodyBuf), 0)
-			p := append(headerbuf, bodyBuf...)
-			c.socket.WriteMessage(websocket.BinaryMessage, p)
+		defer c.pingpongTimer.Stop()
+		defer wg.Done()
+
+		for {
+			select {
+			case <-c.pingpongTimer.C:
+				p, err := c.codec.EncodeHandshake(svc_KEEPALIVE, "\f")
+				if err != nil {
+					continue
+				}
+
+				// writeTimeout으로 묶어서 보낸다. stop이 막 닫혀 writer가
+				// 먼저 빠져나간 경우에도 c.writeCh로 영영 블록되지 않고,
+				// wg.Wait()가 이 고루틴을 기다리다 멈추는 일이 없게 한다.
+				ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+				c.writeContext(ctx, p)
+				cancel()
+			case <-stop:
+				return
+			}
 		}
 	}()
 }
@@ -388,22 +519,14 @@ func (c *Client) createWebsocket() error {
 // setLoginHandshake 메서드는 채팅 서버 연결에
 // 필요한 Login Handshake 데이터를 준비한다.
 func (c *Client) setLoginHandshke() error {
-	var packet []string
-	packet = append(packet, "\f", c.Token.authTicket, "\f", "\f", c.Token.Flag, "\f")
-
-	return c.setHandshakeData(1, packet)
+	return c.setHandshakeData(svc_LOGIN, "\f", c.Token.authTicket, "\f", "\f", c.Token.Flag, "\f")
 }
 
 // setJoinHandshake 메서드는 채팅 서버 연결에
 // 필요한 Join Handshake 데이터를 준비한다.
 func (c *Client) setJoinHandshake() error {
-	infoPacket := append(
-		c.setLogHandshake(defaultLog()),
-		c.setInfoHandshake(defaultInfo(c.channelPassword))...,
-	)
-	var packet []string
-	packet = append(
-		packet,
+	return c.setHandshakeData(
+		svc_JOINCH,
 		"\f",
 		c.Token.chatRoom,
 		"\f",
@@ -413,74 +536,21 @@ func (c *Client) setJoinHandshake() error {
 		"\f",
 		"",
 		"\f",
-		string(infoPacket),
+		defaultLog(),
+		defaultInfo(c.channelPassword),
 		"\f",
 	)
-
-	return c.setHandshakeData(2, packet)
 }
 
 // setHandshakeData 메서드는 아프리카TV 채팅 서버에 연결할 때
-// 필요한 데이터를 생성하는 과정을 수행한다.
-func (c *Client) setHandshakeData(svc int, packet []string) error {
-	bodyBuf := makeBuffer(packet)
-	headerBuf := makeHeader(svc, len(bodyBuf), 0)
-	p := append(headerBuf, bodyBuf...)
+// 필요한 데이터를 Codec을 통해 인코딩하여 c.handshake에 저장한다.
+func (c *Client) setHandshakeData(svc int, fields ...any) error {
+	p, err := c.codec.EncodeHandshake(svc, fields...)
+	if err != nil {
+		return err
+	}
 
 	c.handshake[svc-1] = p
 
 	return nil
 }
-
-// setLogHandshake 메서드는 Handshake 과정 중
-// 필요한 Log 데이터를 가공한다.
-func (c *Client) setLogHandshake(log log) []byte {
-	result := append([]byte("log"), 17)
-	result = append(result, c.setLogValue(log)...)
-	result = append(result, 18)
-
-	return result
-}
-
-// setInfoHandshake 메서드는 Handshake 과정 중
-// 필요한 Info 데이터를 가공한다.
-func (c *Client) setInfoHandshake(info info) []byte {
-	var result []byte
-	infoValue := reflect.ValueOf(info)
-
-	for i := 0; i < infoValue.NumField(); i++ {
-		field := infoValue.Field(i)
-		if !field.IsZero() {
-			k := strings.ToLower(infoValue.Type().Field(i).Tag.Get("json"))
-			v := fmt.Sprintf("%v", field.Interface())
-			kv := append([]byte(k), 17)
-			kv = append(kv, []byte(v)...)
-			kv = append(kv, 18)
-			result = append(result, kv...)
-		}
-	}
-
-	return result
-}
-
-// setLogValue 메서드는 Handshake 과정 중
-// Log 구조체를 []byte 로 변환한다.
-func (c *Client) setLogValue(log log) []byte {
-	var result []byte
-	logValue := reflect.ValueOf(log)
-
-	for i := 0; i < logValue.NumField(); i++ {
-		field := logValue.Field(i)
-		if !field.IsZero() {
-			k := strings.ToLower(logValue.Type().Field(i).Tag.Get("json"))
-			v := fmt.Sprintf("%v", field.Interface())
-			kv := append([]byte{6}, []byte(k)...)
-			kv = append(kv, 6, '=', 6)
-			kv = append(kv, []byte(v)...)
-			kv = append(kv, 6, '&')
-			result = append(result, kv...)
-		}
-	}
-
-	return append([]byte{6, 38}, result...)
-}
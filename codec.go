@@ -0,0 +1,129 @@
+package soopchat
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Codec 인터페이스는 Sooplive 채팅 프로토콜의 프레이밍을
+// 추상화한다. 프로토콜 개정이 있을 때 상태 머신(client.go)을
+// 건드리지 않고 Codec 구현체만 교체할 수 있도록 한다.
+type Codec interface {
+	// EncodeHandshake는 svc 핸드쉐이크에 들어갈 필드들을 인코딩한다.
+	// 필드는 string, log, info 타입을 지원한다.
+	EncodeHandshake(svc int, fields ...any) ([]byte, error)
+	// EncodeChat은 채팅 메시지를 전송 가능한 프레임으로 인코딩한다.
+	EncodeChat(msg string) ([]byte, error)
+	// DecodeFrame은 수신한 프레임에서 서비스 코드와 "\f"로
+	// 구분된 조각을 추출한다. 첫 조각(body[0])은 헤더이며,
+	// 파서들은 body[1]부터 본문으로 읽는다.
+	DecodeFrame(frame []byte) (svc int, body [][]byte, err error)
+}
+
+// DefaultSoopCodec는 아프리카TV/Soop 채팅 서버가 현재 사용하는
+// 프레이밍(헤더 27,9 + svc 4자리 + 본문 길이 6자리 + 옵션 2자리 + body,
+// log/info 필드 구분자 6/17/18/38/61)을
+// 구현한 기본 Codec이다.
+type DefaultSoopCodec struct{}
+
+func (DefaultSoopCodec) EncodeHandshake(svc int, fields ...any) ([]byte, error) {
+	var parts []string
+	for _, f := range fields {
+		switch v := f.(type) {
+		case string:
+			parts = append(parts, v)
+		case log:
+			parts = append(parts, string(encodeLog(v)))
+		case info:
+			parts = append(parts, string(encodeInfo(v)))
+		default:
+			return nil, fmt.Errorf("soopchat: unsupported handshake field type %T", f)
+		}
+	}
+
+	bodyBuf := makeBuffer(parts)
+	headerBuf := makeHeader(svc, len(bodyBuf), 0)
+
+	return append(headerBuf, bodyBuf...), nil
+}
+
+func (DefaultSoopCodec) EncodeChat(msg string) ([]byte, error) {
+	bodyBuf := makeBuffer([]string{"\f", msg, "\f", "0", "\f"})
+	headerBuf := makeHeader(svc_CHATMESG, len(bodyBuf), 0)
+
+	return append(headerBuf, bodyBuf...), nil
+}
+
+func (DefaultSoopCodec) DecodeFrame(frame []byte) (int, [][]byte, error) {
+	svc, err := getServiceCode(frame)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return svc, bytes.Split(frame, []byte("\f")), nil
+}
+
+// encodeLog 함수는 Handshake 과정 중 필요한 Log 데이터를 가공한다.
+func encodeLog(log log) []byte {
+	result := append([]byte("log"), 17)
+	result = append(result, encodeLogValue(log)...)
+	result = append(result, 18)
+
+	return result
+}
+
+// encodeInfo 함수는 Handshake 과정 중 필요한 Info 데이터를 가공한다.
+func encodeInfo(info info) []byte {
+	var result []byte
+	infoValue := reflect.ValueOf(info)
+
+	for i := 0; i < infoValue.NumField(); i++ {
+		field := infoValue.Field(i)
+		if !field.IsZero() {
+			k := strings.ToLower(infoValue.Type().Field(i).Tag.Get("json"))
+			v := fmt.Sprintf("%v", field.Interface())
+			kv := append([]byte(k), 17)
+			kv = append(kv, []byte(v)...)
+			kv = append(kv, 18)
+			result = append(result, kv...)
+		}
+	}
+
+	return result
+}
+
+// encodeLogValue 함수는 Log 구조체를 []byte로 변환한다.
+func encodeLogValue(log log) []byte {
+	var result []byte
+	logValue := reflect.ValueOf(log)
+
+	for i := 0; i < logValue.NumField(); i++ {
+		field := logValue.Field(i)
+		if !field.IsZero() {
+			k := strings.ToLower(logValue.Type().Field(i).Tag.Get("json"))
+			v := fmt.Sprintf("%v", field.Interface())
+			kv := append([]byte{6}, []byte(k)...)
+			kv = append(kv, 6, '=', 6)
+			kv = append(kv, []byte(v)...)
+			kv = append(kv, 6, '&')
+			result = append(result, kv...)
+		}
+	}
+
+	return append([]byte{6, 38}, result...)
+}
+
+// Option 함수는 NewClient가 Client를 생성할 때 적용할
+// 추가 설정을 나타낸다.
+type Option func(*Client)
+
+// WithCodec 함수는 핸드쉐이크/채팅 메시지 인코딩과 수신 프레임
+// 디코딩에 사용할 Codec을 지정한다. 지정하지 않으면
+// DefaultSoopCodec이 사용된다.
+func WithCodec(codec Codec) Option {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
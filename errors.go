@@ -0,0 +1,52 @@
+package soopchat
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// ErrChatClosed는 서버가 정상적으로 연결을 종료했을 때 반환된다.
+	ErrChatClosed = errors.New("soopchat: chat connection closed")
+	// ErrProtocol은 서버가 프로토콜 오류를 이유로 연결을 종료했을 때 반환된다.
+	ErrProtocol = errors.New("soopchat: protocol error")
+	// ErrAbnormalClose는 정상적인 종료 절차 없이 연결이 끊어졌을 때 반환된다.
+	ErrAbnormalClose = errors.New("soopchat: abnormal closure")
+	// ErrIdleTimeout은 keepalive 응답을 포함해 어떤 프레임도
+	// WithKeepalive의 timeout 동안 수신하지 못했을 때 반환된다.
+	ErrIdleTimeout = errors.New("soopchat: idle read timeout")
+)
+
+// readItem 구조체는 reader 고루틴이 c.read 채널로 전달하는
+// 하나의 읽기 결과를 나타낸다. msg와 err 중 하나만 채워진다.
+type readItem struct {
+	msg []byte
+	err error
+}
+
+// classifyReadError 함수는 웹소켓에서 발생한 에러를
+// ErrIdleTimeout/ErrChatClosed/ErrProtocol/ErrAbnormalClose 중
+// 하나로 감싸 호출자가 errors.Is로 원인을 구분할 수 있게 한다.
+func classifyReadError(err error) error {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("%w: %v", ErrIdleTimeout, err)
+	}
+
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		switch closeErr.Code {
+		case websocket.CloseNormalClosure, websocket.CloseGoingAway:
+			return fmt.Errorf("%w: %v", ErrChatClosed, err)
+		case websocket.CloseProtocolError, websocket.CloseUnsupportedData, websocket.CloseInvalidFramePayloadData:
+			return fmt.Errorf("%w: %v", ErrProtocol, err)
+		default:
+			return fmt.Errorf("%w: %v", ErrAbnormalClose, err)
+		}
+	}
+
+	return fmt.Errorf("%w: %v", ErrAbnormalClose, err)
+}
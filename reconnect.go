@@ -0,0 +1,47 @@
+package soopchat
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ReconnectPolicy 구조체는 웹소켓 연결이 끊어졌을 때
+// Client가 재연결을 시도하는 방식을 정의한다.
+// MaxRetries가 0이면 재연결을 시도하지 않는다.
+type ReconnectPolicy struct {
+	MaxRetries     int           // 최대 재시도 횟수
+	InitialBackoff time.Duration // 첫 재시도까지 대기 시간
+	MaxBackoff     time.Duration // 지터를 더하기 전 재시도 대기 시간의 상한
+	Jitter         float64       // 대기 시간에 곱해지는 무작위 지터 비율 (0 ~ 1)
+}
+
+// DefaultReconnectPolicy 함수는 일반적인 네트워크 환경에 적합한
+// 기본 재연결 정책을 반환한다.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		MaxRetries:     5,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     30 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+// backoff 메서드는 주어진 시도 횟수에 맞는 대기 시간을
+// 지수적으로 계산하고, MaxBackoff로 상한을 둔 뒤 지터를 더해 반환한다.
+func (p ReconnectPolicy) backoff(attempt int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(2, float64(attempt))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		d += d * p.Jitter * rand.Float64()
+	}
+
+	return time.Duration(d)
+}
@@ -0,0 +1,20 @@
+package soopchat
+
+// EventSink 인터페이스는 Client(실시간 웹소켓)와 webhook.Server(HTTP
+// 릴레이)처럼 서로 다른 전송 방식으로 들어오는 이벤트를 동일한 콜백
+// 집합으로 내보내는 타입이 구현해야 하는 콜백 등록 메서드를 정의한다.
+// 덕분에 하위 애플리케이션은 전송 방식에 상관없이 같은 코드로
+// 이벤트를 소비할 수 있다.
+type EventSink interface {
+	OnError(callback func(err error))
+	OnRawMessage(callback func(message string))
+	OnChatMessage(callback func(message ChatMessage))
+	OnUserLists(callback func(userlist []UserList))
+	OnBalloon(callback func(balloon Balloon))
+	OnAdballoon(callback func(adballoon Adballoon))
+	OnSubscription(callback func(subscription Subscription))
+	OnAdminNotice(callback func(message string))
+	OnMission(callback func(mission Mission))
+}
+
+var _ EventSink = (*Client)(nil)
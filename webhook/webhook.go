@@ -0,0 +1,208 @@
+// Package webhook은 실시간 웹소켓 연결 대신, 릴레이 서버가 HTTP POST로
+// 재전송하는 채팅/별풍선/구독 이벤트를 받아 soopchat.Client와 동일한
+// 콜백 집합(soopchat.EventSink)으로 전달하는 http.Handler를 제공한다.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/halfdogs/soopchat"
+)
+
+// signatureHeader는 본문에 대한 HMAC-SHA256 서명을 담는 헤더 이름이다.
+// 값은 "sha256=<hex>" 형식이어야 한다.
+const signatureHeader = "X-Soopchat-Signature"
+
+const defaultMaxBodySize = 1 << 20 // 1MiB
+
+// Server는 soopchat.EventSink와 http.Handler를 모두 구현한다.
+// HMAC-SHA256으로 서명된 이벤트를 POST로 받아 콜백으로 재전달한다.
+type Server struct {
+	secret      string
+	maxBodySize int64
+
+	onError        func(err error)
+	onRawMessage   func(message string)
+	onChatMessage  func(message soopchat.ChatMessage)
+	onUserLists    func(userlist []soopchat.UserList)
+	onBalloon      func(balloon soopchat.Balloon)
+	onAdballoon    func(adballoon soopchat.Adballoon)
+	onSubscription func(subscription soopchat.Subscription)
+	onAdminNotice  func(message string)
+	onMission      func(mission soopchat.Mission)
+}
+
+var (
+	_ soopchat.EventSink = (*Server)(nil)
+	_ http.Handler       = (*Server)(nil)
+)
+
+// Option 함수는 NewWebhookServer가 Server를 생성할 때
+// 적용할 추가 설정을 나타낸다.
+type Option func(*Server)
+
+// WithMaxBodySize 함수는 허용할 최대 요청 본문 크기를 지정한다.
+// 지정하지 않으면 1MiB가 사용된다.
+func WithMaxBodySize(n int64) Option {
+	return func(s *Server) {
+		s.maxBodySize = n
+	}
+}
+
+// NewWebhookServer 함수는 secret으로 서명을 검증하는
+// webhook http.Handler를 생성한다.
+func NewWebhookServer(secret string, opts ...Option) *Server {
+	s := &Server{
+		secret:      secret,
+		maxBodySize: defaultMaxBodySize,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Server) OnError(callback func(err error))            { s.onError = callback }
+func (s *Server) OnRawMessage(callback func(message string))  { s.onRawMessage = callback }
+func (s *Server) OnAdminNotice(callback func(message string)) { s.onAdminNotice = callback }
+func (s *Server) OnMission(callback func(mission soopchat.Mission)) {
+	s.onMission = callback
+}
+func (s *Server) OnChatMessage(callback func(message soopchat.ChatMessage)) {
+	s.onChatMessage = callback
+}
+func (s *Server) OnUserLists(callback func(userlist []soopchat.UserList)) {
+	s.onUserLists = callback
+}
+func (s *Server) OnBalloon(callback func(balloon soopchat.Balloon)) {
+	s.onBalloon = callback
+}
+func (s *Server) OnAdballoon(callback func(adballoon soopchat.Adballoon)) {
+	s.onAdballoon = callback
+}
+func (s *Server) OnSubscription(callback func(subscription soopchat.Subscription)) {
+	s.onSubscription = callback
+}
+
+// event는 릴레이가 보내는 POST 본문의 겉봉투 형식이다.
+// payload는 type에 따라 soopchat.ChatMessage/Balloon/Subscription 중
+// 하나로 디코딩된다.
+type event struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ServeHTTP 메서드는 http.Handler를 구현한다.
+// 서명이 없거나 일치하지 않으면 401을, 본문을 읽거나 event 봉투를
+// 디코딩할 수 없으면 400을 반환한다. payload 디코딩 실패나 알 수 없는
+// type은 onError로 전달하고 200을 반환한다.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, s.maxBodySize+1))
+	if err != nil {
+		s.reportError(fmt.Errorf("soopchat/webhook: read body: %w", err))
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > s.maxBodySize {
+		http.Error(w, "request entity too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := s.verifySignature(r.Header.Get(signatureHeader), body); err != nil {
+		s.reportError(err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if s.onRawMessage != nil {
+		s.onRawMessage(string(body))
+	}
+
+	var e event
+	if err := json.Unmarshal(body, &e); err != nil {
+		s.reportError(fmt.Errorf("soopchat/webhook: decode event: %w", err))
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.dispatch(e); err != nil {
+		s.reportError(err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifySignature 메서드는 본문에 대한 HMAC-SHA256 서명이
+// secret으로 계산한 값과 일치하는지 검사한다.
+func (s *Server) verifySignature(header string, body []byte) error {
+	const prefix = "sha256="
+	if len(header) <= len(prefix) || header[:len(prefix)] != prefix {
+		return errors.New("soopchat/webhook: missing or malformed signature header")
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	got, err := hex.DecodeString(header[len(prefix):])
+	if err != nil || !hmac.Equal(got, expected) {
+		return errors.New("soopchat/webhook: signature mismatch")
+	}
+
+	return nil
+}
+
+// dispatch 메서드는 event의 타입에 맞춰 본문을 디코딩하고
+// Client와 동일한 콜백으로 전달한다.
+func (s *Server) dispatch(e event) error {
+	switch e.Type {
+	case "chat_message":
+		var m soopchat.ChatMessage
+		if err := json.Unmarshal(e.Payload, &m); err != nil {
+			return fmt.Errorf("soopchat/webhook: decode chat_message: %w", err)
+		}
+		if s.onChatMessage != nil {
+			s.onChatMessage(m)
+		}
+	case "balloon":
+		var m soopchat.Balloon
+		if err := json.Unmarshal(e.Payload, &m); err != nil {
+			return fmt.Errorf("soopchat/webhook: decode balloon: %w", err)
+		}
+		if s.onBalloon != nil {
+			s.onBalloon(m)
+		}
+	case "subscription":
+		var m soopchat.Subscription
+		if err := json.Unmarshal(e.Payload, &m); err != nil {
+			return fmt.Errorf("soopchat/webhook: decode subscription: %w", err)
+		}
+		if s.onSubscription != nil {
+			s.onSubscription(m)
+		}
+	default:
+		return fmt.Errorf("soopchat/webhook: unknown event type %q", e.Type)
+	}
+
+	return nil
+}
+
+func (s *Server) reportError(err error) {
+	if s.onError != nil {
+		s.onError(err)
+	}
+}
@@ -0,0 +1,63 @@
+package soopchat
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// writeTimeout은 한 번의 쓰기에 허용하는 최대 시간이다.
+const writeTimeout = 5 * time.Second
+
+// writeRequest 구조체는 writer 고루틴에 보낼 하나의 쓰기 요청을 나타낸다.
+// errCh로 쓰기 결과(성공 시 nil)를 돌려받는다.
+type writeRequest struct {
+	data  []byte
+	errCh chan error
+}
+
+// writer 메서드는 소켓에 대한 모든 쓰기를 직렬화하는 단일 고루틴이다.
+// gorilla/websocket은 동시 쓰기를 허용하지 않으므로, 핸드쉐이크/
+// KEEPALIVE/SendChatMessage가 모두 이 고루틴을 거쳐 기록된다.
+// stop 채널이 닫히면 고루틴을 종료한다. wg는 processSocket이 재연결
+// 전에 이 고루틴이 완전히 끝났는지 확인하는 데 쓰인다.
+func (c *Client) writer(stop <-chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		select {
+		case req := <-c.writeCh:
+			c.socket.SetWriteDeadline(time.Now().Add(writeTimeout))
+			req.errCh <- c.socket.WriteMessage(websocket.BinaryMessage, req.data)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// write 메서드는 data를 writer 고루틴에 맡기고 기록이
+// 끝날 때까지 대기한다.
+func (c *Client) write(data []byte) error {
+	return c.writeContext(context.Background(), data)
+}
+
+// writeContext 메서드는 write와 같지만, 기록이 끝나기 전에
+// ctx가 취소되면 즉시 반환한다.
+func (c *Client) writeContext(ctx context.Context, data []byte) error {
+	req := writeRequest{data: data, errCh: make(chan error, 1)}
+
+	select {
+	case c.writeCh <- req:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-req.errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}